@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/cdproto/target"
+	"github.com/chromedp/chromedp"
+)
+
+// GlobalBrowser is the single headless Chrome instance shared by all tests
+// in this package. Spinning up Chrome is the expensive part of each test;
+// tests isolate themselves by opening a fresh tab (and browser context, so
+// Service Worker state from one test can't bleed into the next) rather than
+// each starting their own browser.
+var GlobalBrowser context.Context
+
+func TestMain(m *testing.M) {
+	opts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("headless", true),
+		chromedp.Flag("disable-gpu", true),
+		chromedp.Flag("no-sandbox", true),
+		chromedp.Flag("js-flags", "--expose-gc"),
+	)
+	allocCtx, cancelAlloc := chromedp.NewExecAllocator(context.Background(), opts...)
+	ctx, cancelCtx := chromedp.NewContext(allocCtx)
+
+	// Force the browser to actually start now rather than lazily on first
+	// tab, so its startup cost isn't charged to whichever test runs first.
+	if err := chromedp.Run(ctx); err != nil {
+		log.Fatalf("failed to start shared browser: %v", err)
+	}
+	GlobalBrowser = ctx
+
+	code := m.Run()
+
+	cancelCtx()
+	cancelAlloc()
+	os.Exit(code)
+}
+
+// newTab opens a fresh tab in its own browser context off of GlobalBrowser,
+// so cookies, Service Worker registrations and caches from one test are
+// invisible to the next. The tab is closed automatically via t.Cleanup.
+func newTab(t *testing.T, timeout time.Duration) context.Context {
+	t.Helper()
+
+	browserCtxID, err := target.CreateBrowserContext().Do(GlobalBrowser)
+	if err != nil {
+		t.Fatalf("failed to create browser context: %v", err)
+	}
+
+	targetID, err := target.CreateTarget("about:blank").WithBrowserContextID(browserCtxID).Do(GlobalBrowser)
+	if err != nil {
+		t.Fatalf("failed to create target: %v", err)
+	}
+
+	ctx, cancel := chromedp.NewContext(GlobalBrowser, chromedp.WithTargetID(targetID))
+	ctx, cancelTimeout := context.WithTimeout(ctx, timeout)
+
+	attachConsoleCapture(t, ctx)
+
+	t.Cleanup(func() {
+		cancelTimeout()
+		cancel()
+		if err := target.DisposeBrowserContext(browserCtxID).Do(GlobalBrowser); err != nil {
+			t.Logf("failed to dispose browser context: %v", err)
+		}
+	})
+
+	return ctx
+}
+
+// attachConsoleCapture logs browser console output to t.Logf, prefixed with
+// the test name, so failures can be diagnosed without re-running headed.
+func attachConsoleCapture(t *testing.T, ctx context.Context) {
+	t.Helper()
+	name := t.Name()
+	chromedp.ListenTarget(ctx, func(ev interface{}) {
+		call, ok := ev.(*runtime.EventConsoleAPICalled)
+		if !ok {
+			return
+		}
+		var parts []string
+		for _, arg := range call.Args {
+			parts = append(parts, string(arg.Value))
+		}
+		t.Logf("[%s console.%s] %v", name, call.Type, parts)
+	})
+}
+
+// serveDir starts an HTTP server for dir on a random free port and returns
+// its base URL. The server is torn down automatically via t.Cleanup so
+// parallel tests never collide on a fixed port.
+func serveDir(t *testing.T, dir string) string {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.Handle("/", addCOOPHeaders(http.FileServer(http.Dir(dir))))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	server := &http.Server{Handler: mux}
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			t.Logf("server error: %v", err)
+		}
+	}()
+
+	t.Cleanup(func() {
+		server.Close()
+	})
+
+	return fmt.Sprintf("http://%s", listener.Addr().String())
+}
+
+// addCOOPHeaders adds the Cross-Origin headers required for WASM.
+func addCOOPHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cross-Origin-Opener-Policy", "same-origin")
+		w.Header().Set("Cross-Origin-Embedder-Policy", "require-corp")
+		next.ServeHTTP(w, r)
+	})
+}
@@ -3,8 +3,6 @@ package tests
 import (
 	"context"
 	"fmt"
-	"net"
-	"net/http"
 	"testing"
 	"time"
 
@@ -12,45 +10,19 @@ import (
 )
 
 const (
-	testPort           = "8082"
-	maxMemoryGrowthMB  = 50 // Maximum acceptable memory growth in MB
-	numRecordings      = 20 // Number of recordings to simulate
+	maxMemoryGrowthMB = 50 // Maximum acceptable memory growth in MB
+	numRecordings     = 20 // Number of recordings to simulate
 )
 
 // TestMemoryLeak verifies that repeated recordings don't cause memory leaks
 func TestMemoryLeak(t *testing.T) {
-	// Start test server
-	server := startTestServer(t)
-	defer server.Close()
-
-	// Setup Chrome context
-	// IMPORTANT: Use fresh user-data-dir to prevent Service Worker cache issues.
-	// Service Worker persists across test runs in the same profile, causing tests
-	// to serve stale cached versions instead of latest dist/pwa/ build.
-	// Fresh profile ensures deterministic test environment.
-	opts := append(chromedp.DefaultExecAllocatorOptions[:],
-		chromedp.Flag("headless", true),
-		chromedp.Flag("disable-gpu", true),
-		chromedp.Flag("no-sandbox", true),
-		chromedp.Flag("js-flags", "--expose-gc"), // Enable manual GC
-		chromedp.Flag("user-data-dir", t.TempDir()), // Fresh profile per test
-	)
-	allocCtx, cancelAlloc := chromedp.NewExecAllocator(context.Background(), opts...)
-	defer cancelAlloc()
-
-	ctx, cancelCtx := chromedp.NewContext(allocCtx)
-	defer func() {
-		// Explicitly shutdown Chrome and wait for cleanup
-		cancelCtx()
-		time.Sleep(500 * time.Millisecond) // Give Chrome time to release file handles
-	}()
+	t.Parallel()
 
-	// Set timeout
-	ctx, cancelTimeout := context.WithTimeout(ctx, 30*time.Second)
-	defer cancelTimeout()
+	baseURL := serveDir(t, "../dist/pwa")
+	ctx := newTab(t, 30*time.Second)
 
 	// Navigate to app
-	url := fmt.Sprintf("http://localhost:%s/voxalpha.html", testPort)
+	url := fmt.Sprintf("%s/voxalpha.html", baseURL)
 	t.Logf("Opening %s", url)
 
 	if err := chromedp.Run(ctx, chromedp.Navigate(url)); err != nil {
@@ -270,44 +242,3 @@ func waitForCleanup(ctx context.Context, timeout time.Duration) error {
 		}
 	}
 }
-
-// startTestServer starts a test HTTP server serving the dist directory
-func startTestServer(t *testing.T) *http.Server {
-	t.Helper()
-
-	mux := http.NewServeMux()
-
-	// Serve dist/pwa directory (PWA build output)
-	fs := http.FileServer(http.Dir("../dist/pwa"))
-	mux.Handle("/", addCOOPHeaders(fs))
-
-	server := &http.Server{
-		Addr:    ":" + testPort,
-		Handler: mux,
-	}
-
-	listener, err := net.Listen("tcp", server.Addr)
-	if err != nil {
-		t.Fatalf("Failed to start test server: %v", err)
-	}
-
-	go func() {
-		if err := server.Serve(listener); err != http.ErrServerClosed {
-			t.Logf("Server error: %v", err)
-		}
-	}()
-
-	t.Logf("Test server started on http://localhost:%s", testPort)
-	time.Sleep(500 * time.Millisecond) // Give server time to start
-
-	return server
-}
-
-// addCOOPHeaders adds Cross-Origin headers required for WASM
-func addCOOPHeaders(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Cross-Origin-Opener-Policy", "same-origin")
-		w.Header().Set("Cross-Origin-Embedder-Policy", "require-corp")
-		next.ServeHTTP(w, r)
-	})
-}
@@ -0,0 +1,140 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+var updateGoldens = flag.Bool("update", false, "rewrite transcription goldens instead of comparing against them")
+
+// defaultMaxWER is the word-error-rate budget for a fixture that doesn't
+// carry its own <name>.threshold file.
+const defaultMaxWER = 0.2
+
+// TestTranscriptionAccuracy runs every WAV fixture in tests/fixtures through
+// the real Whisper wrapper (via a JS bridge that injects PCM samples
+// directly, bypassing the microphone) and checks the resulting transcript
+// against a golden text file by word error rate. Run with -update to
+// regenerate the goldens after an intentional model/wrapper change.
+func TestTranscriptionAccuracy(t *testing.T) {
+	t.Parallel()
+
+	fixtures, err := filepath.Glob("fixtures/*.wav")
+	if err != nil {
+		t.Fatalf("glob fixtures: %v", err)
+	}
+	if len(fixtures) == 0 {
+		t.Skip("no fixtures under tests/fixtures")
+	}
+
+	baseURL := serveDir(t, "../dist/pwa")
+
+	for _, wavPath := range fixtures {
+		wavPath := wavPath
+		name := strings.TrimSuffix(filepath.Base(wavPath), ".wav")
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			samples, err := readWAVPCM(wavPath)
+			if err != nil {
+				t.Fatalf("read fixture: %v", err)
+			}
+
+			ctx := newTab(t, 30*time.Second)
+			url := fmt.Sprintf("%s/voxalpha.html", baseURL)
+			if err := chromedp.Run(ctx, chromedp.Navigate(url)); err != nil {
+				t.Fatalf("navigate: %v", err)
+			}
+			time.Sleep(2 * time.Second) // let the WASM model finish loading
+
+			got, err := injectAndTranscribe(ctx, samples)
+			if err != nil {
+				t.Fatalf("transcribe: %v", err)
+			}
+
+			goldenPath := filepath.Join("fixtures", name+".txt")
+			if *updateGoldens {
+				if err := os.WriteFile(goldenPath, []byte(got+"\n"), 0644); err != nil {
+					t.Fatalf("write golden: %v", err)
+				}
+				t.Logf("UPDATED %s", goldenPath)
+				return
+			}
+
+			wantBytes, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("missing golden %s (run with -update to create it): %v", goldenPath, err)
+			}
+			want := string(wantBytes)
+
+			wer := wordErrorRate(got, want)
+			maxWER := thresholdFor(name)
+			t.Logf("got: %q want: %q WER: %.2f%% (limit %.2f%%)", got, want, wer*100, maxWER*100)
+			if wer > maxWER {
+				t.Errorf("WER %.2f%% exceeds limit %.2f%% for %s", wer*100, maxWER*100, name)
+			}
+		})
+	}
+}
+
+// thresholdFor returns the fixture's own WER budget from
+// fixtures/<name>.threshold, or defaultMaxWER if it has none.
+func thresholdFor(name string) float64 {
+	data, err := os.ReadFile(filepath.Join("fixtures", name+".threshold"))
+	if err != nil {
+		return defaultMaxWER
+	}
+	var v float64
+	if _, err := fmt.Sscanf(string(data), "%f", &v); err != nil {
+		return defaultMaxWER
+	}
+	return v
+}
+
+// injectAndTranscribe feeds PCM samples to the page's Whisper wrapper
+// through a JS bridge and waits for the resulting transcript.
+func injectAndTranscribe(ctx context.Context, samples []float32) (string, error) {
+	encoded, err := json.Marshal(samples)
+	if err != nil {
+		return "", fmt.Errorf("marshal samples: %w", err)
+	}
+
+	inject := fmt.Sprintf(`
+		(function() {
+			window.__transcriptionDone = false;
+			window.__voxalphaInjectPCM(new Float32Array(%s));
+		})();
+	`, encoded)
+
+	if err := chromedp.Run(ctx, chromedp.Evaluate(inject, nil)); err != nil {
+		return "", fmt.Errorf("inject PCM: %w", err)
+	}
+
+	deadline := time.Now().Add(15 * time.Second)
+	for time.Now().Before(deadline) {
+		var done bool
+		if err := chromedp.Run(ctx, chromedp.Evaluate(`window.__transcriptionDone || false`, &done)); err != nil {
+			return "", fmt.Errorf("poll transcript: %w", err)
+		}
+		if done {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	var transcript string
+	if err := chromedp.Run(ctx, chromedp.Text("#transcript", &transcript, chromedp.NodeVisible)); err != nil {
+		return "", fmt.Errorf("read transcript: %w", err)
+	}
+	return strings.TrimSpace(transcript), nil
+}
@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+)
+
+// readWAVPCM reads a 16-bit PCM mono WAV file and returns its samples as
+// float32 in [-1, 1], matching the format whisper-wrapper.js expects from
+// the microphone pipeline.
+func readWAVPCM(path string) ([]float32, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	if len(data) < 44 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return nil, fmt.Errorf("%s: not a RIFF/WAVE file", path)
+	}
+
+	var bitsPerSample, channels uint16
+	var dataOffset, dataLen int
+	offset := 12
+	for offset+8 <= len(data) {
+		chunkID := string(data[offset : offset+4])
+		chunkSize := int(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))
+		body := offset + 8
+
+		switch chunkID {
+		case "fmt ":
+			channels = binary.LittleEndian.Uint16(data[body+2 : body+4])
+			bitsPerSample = binary.LittleEndian.Uint16(data[body+14 : body+16])
+		case "data":
+			dataOffset = body
+			dataLen = chunkSize
+		}
+
+		offset = body + chunkSize
+		if chunkSize%2 == 1 {
+			offset++ // chunks are word-aligned
+		}
+	}
+
+	if dataOffset == 0 {
+		return nil, fmt.Errorf("%s: missing data chunk", path)
+	}
+	if bitsPerSample != 16 || channels != 1 {
+		return nil, fmt.Errorf("%s: only 16-bit mono WAV is supported, got %d-bit/%d channels", path, bitsPerSample, channels)
+	}
+
+	numSamples := dataLen / 2
+	samples := make([]float32, numSamples)
+	for i := 0; i < numSamples; i++ {
+		raw := int16(binary.LittleEndian.Uint16(data[dataOffset+i*2 : dataOffset+i*2+2]))
+		samples[i] = float32(raw) / 32768
+	}
+	return samples, nil
+}
@@ -0,0 +1,49 @@
+package tests
+
+import "strings"
+
+// wordErrorRate computes WER between a hypothesis and reference transcript
+// as a word-level Levenshtein edit distance normalized by the reference
+// length. Comparison is case-insensitive and ignores punctuation-only
+// whitespace differences.
+func wordErrorRate(hypothesis, reference string) float64 {
+	ref := strings.Fields(strings.ToLower(reference))
+	hyp := strings.Fields(strings.ToLower(hypothesis))
+	if len(ref) == 0 {
+		if len(hyp) == 0 {
+			return 0
+		}
+		return 1
+	}
+
+	// Standard DP edit distance over words (substitution, insertion, deletion).
+	prev := make([]int, len(hyp)+1)
+	curr := make([]int, len(hyp)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ref); i++ {
+		curr[0] = i
+		for j := 1; j <= len(hyp); j++ {
+			if ref[i-1] == hyp[j-1] {
+				curr[j] = prev[j-1]
+			} else {
+				curr[j] = 1 + min3(prev[j], curr[j-1], prev[j-1])
+			}
+		}
+		prev, curr = curr, prev
+	}
+
+	return float64(prev[len(hyp)]) / float64(len(ref))
+}
+
+func min3(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
@@ -0,0 +1,26 @@
+// Package size parses the "WxH" dimension strings used throughout the
+// tools/ DSLs (windowsize, viewport, ...).
+package size
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Parse parses a "WxH" string such as "1280x720" into its width and height.
+func Parse(s string) (width, height int, err error) {
+	parts := strings.SplitN(s, "x", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("must be WxH, got %q", s)
+	}
+	width, err = strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid width in %q: %w", s, err)
+	}
+	height, err = strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid height in %q: %w", s, err)
+	}
+	return width, height, nil
+}
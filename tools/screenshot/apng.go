@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"io"
+)
+
+var pngSignature = []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}
+
+type pngChunk struct {
+	typ  [4]byte
+	data []byte
+}
+
+func parsePNGChunks(data []byte) ([]pngChunk, error) {
+	if len(data) < len(pngSignature) || !bytes.Equal(data[:len(pngSignature)], pngSignature) {
+		return nil, fmt.Errorf("not a PNG stream")
+	}
+	var chunks []pngChunk
+	rest := data[len(pngSignature):]
+	for len(rest) > 0 {
+		if len(rest) < 8 {
+			return nil, fmt.Errorf("truncated chunk header")
+		}
+		length := binary.BigEndian.Uint32(rest[0:4])
+		var typ [4]byte
+		copy(typ[:], rest[4:8])
+		if uint32(len(rest)) < 8+length+4 {
+			return nil, fmt.Errorf("truncated chunk %s", typ)
+		}
+		chunkData := rest[8 : 8+length]
+		chunks = append(chunks, pngChunk{typ: typ, data: append([]byte(nil), chunkData...)})
+		rest = rest[8+length+4:]
+	}
+	return chunks, nil
+}
+
+func writeChunk(w io.Writer, typ string, data []byte) error {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(data)))
+	if _, err := w.Write(length[:]); err != nil {
+		return err
+	}
+	crc := crc32.NewIEEE()
+	crc.Write([]byte(typ))
+	crc.Write(data)
+	if _, err := io.WriteString(w, typ); err != nil {
+		return err
+	}
+	if len(data) > 0 {
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	var sum [4]byte
+	binary.BigEndian.PutUint32(sum[:], crc.Sum32())
+	_, err := w.Write(sum[:])
+	return err
+}
+
+// encodeAPNG writes frames as an Animated PNG, looping forever, with each
+// frame shown for 1/fps seconds. Frames must all share the same bounds.
+func encodeAPNG(w io.Writer, frames []image.Image, fps int) error {
+	if len(frames) == 0 {
+		return fmt.Errorf("no frames to encode")
+	}
+
+	type encoded struct {
+		ihdr, plte, trns []byte
+		idat             []byte
+	}
+	encodedFrames := make([]encoded, len(frames))
+	for i, f := range frames {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, f); err != nil {
+			return fmt.Errorf("encode frame %d: %w", i, err)
+		}
+		chunks, err := parsePNGChunks(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("parse frame %d: %w", i, err)
+		}
+		var ef encoded
+		var idat bytes.Buffer
+		for _, c := range chunks {
+			switch string(c.typ[:]) {
+			case "IHDR":
+				ef.ihdr = c.data
+			case "PLTE":
+				ef.plte = c.data
+			case "tRNS":
+				ef.trns = c.data
+			case "IDAT":
+				idat.Write(c.data)
+			}
+		}
+		ef.idat = idat.Bytes()
+		encodedFrames[i] = ef
+	}
+
+	bounds := frames[0].Bounds()
+	if _, err := w.Write(pngSignature); err != nil {
+		return err
+	}
+	if err := writeChunk(w, "IHDR", encodedFrames[0].ihdr); err != nil {
+		return err
+	}
+	if encodedFrames[0].plte != nil {
+		if err := writeChunk(w, "PLTE", encodedFrames[0].plte); err != nil {
+			return err
+		}
+	}
+	if encodedFrames[0].trns != nil {
+		if err := writeChunk(w, "tRNS", encodedFrames[0].trns); err != nil {
+			return err
+		}
+	}
+
+	var actl [8]byte
+	binary.BigEndian.PutUint32(actl[0:4], uint32(len(frames)))
+	binary.BigEndian.PutUint32(actl[4:8], 0) // num_plays = 0 means loop forever
+	if err := writeChunk(w, "acTL", actl[:]); err != nil {
+		return err
+	}
+
+	seq := uint32(0)
+	for i, ef := range encodedFrames {
+		fctl := make([]byte, 26)
+		binary.BigEndian.PutUint32(fctl[0:4], seq)
+		binary.BigEndian.PutUint32(fctl[4:8], uint32(bounds.Dx()))
+		binary.BigEndian.PutUint32(fctl[8:12], uint32(bounds.Dy()))
+		binary.BigEndian.PutUint32(fctl[12:16], 0)           // x_offset
+		binary.BigEndian.PutUint32(fctl[16:20], 0)           // y_offset
+		binary.BigEndian.PutUint16(fctl[20:22], 1)           // delay_num
+		binary.BigEndian.PutUint16(fctl[22:24], uint16(fps)) // delay_den
+		fctl[24] = 0                                         // dispose_op: none
+		fctl[25] = 0                                         // blend_op: source
+		if err := writeChunk(w, "fcTL", fctl); err != nil {
+			return err
+		}
+		seq++
+
+		if i == 0 {
+			if err := writeChunk(w, "IDAT", ef.idat); err != nil {
+				return err
+			}
+			continue
+		}
+
+		fdat := make([]byte, 4+len(ef.idat))
+		binary.BigEndian.PutUint32(fdat[0:4], seq)
+		copy(fdat[4:], ef.idat)
+		if err := writeChunk(w, "fdAT", fdat); err != nil {
+			return err
+		}
+		seq++
+	}
+
+	return writeChunk(w, "IEND", nil)
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	"github.com/ericpauley/go-quantize/quantize"
+)
+
+// demoMaxWait bounds how long captureFrames waits for the transcript to
+// appear before giving up. demoTail is how much longer it keeps recording
+// afterwards, so the transcript is visible for a beat before the file ends.
+const (
+	demoMaxWait = 10 * time.Second
+	demoTail    = 2 * time.Second
+)
+
+// recordDemo drives a scripted navigate -> click record -> speak-mock -> wait
+// for transcript flow while grabbing frames at fps, then encodes them into a
+// single animated file under screenshotsDir named demo.<format>.
+func recordDemo(url, screenshotsDir, format string, colors int, scale float64, fps int) error {
+	if colors < 2 || colors > 256 {
+		return fmt.Errorf("-colors must be between 2 and 256, got %d", colors)
+	}
+	if fps <= 0 {
+		return fmt.Errorf("-fps must be positive, got %d", fps)
+	}
+
+	opts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("headless", true),
+		chromedp.Flag("disable-gpu", true),
+		chromedp.Flag("no-sandbox", true),
+	)
+	allocCtx, cancel := chromedp.NewExecAllocator(context.Background(), opts...)
+	defer cancel()
+
+	ctx, cancel := chromedp.NewContext(allocCtx)
+	defer cancel()
+
+	ctx, cancel = context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	if err := chromedp.Run(ctx,
+		chromedp.EmulateViewport(1280, 720),
+		chromedp.Navigate(url),
+		chromedp.Sleep(2*time.Second), // let the app and WASM model finish loading
+	); err != nil {
+		return fmt.Errorf("navigate: %w", err)
+	}
+
+	// Start the scripted interaction: click the record button, then mock a
+	// spoken utterance so a transcript appears while frames are captured.
+	if err := chromedp.Run(ctx,
+		chromedp.Click("#record-button", chromedp.ByID, chromedp.NodeVisible),
+		chromedp.Evaluate(mockSpeechScript, nil),
+	); err != nil {
+		return fmt.Errorf("start recording: %w", err)
+	}
+
+	fmt.Printf("Recording %s demo at %d fps, waiting up to %s for the transcript...\n", format, fps, demoMaxWait)
+
+	frames, err := captureFrames(ctx, fps, demoMaxWait, demoTail)
+	if err != nil {
+		return fmt.Errorf("capture frames: %w", err)
+	}
+	if scale != 1.0 {
+		frames = scaleFrames(frames, scale)
+	}
+
+	outFile := filepath.Join(screenshotsDir, "demo."+format)
+	f, err := os.Create(outFile)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", outFile, err)
+	}
+	defer f.Close()
+
+	switch format {
+	case "gif":
+		err = encodeGIF(f, frames, colors, fps)
+	case "apng":
+		err = encodeAPNG(f, frames, fps)
+	case "png":
+		// A still sequence: write the final frame, matching a single
+		// screenshot capture rather than an animation.
+		err = encodeAPNG(f, frames[len(frames)-1:], fps)
+	default:
+		return fmt.Errorf("unknown -format %q (want gif, png, or apng)", format)
+	}
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", format, err)
+	}
+
+	fmt.Printf("✓ Saved demo: %s (%d frames)\n", outFile, len(frames))
+	return nil
+}
+
+// mockSpeechScript simulates a spoken utterance without needing a
+// microphone, mirroring the PCM injection used by the memory-leak test.
+const mockSpeechScript = `
+	window.dispatchEvent(new CustomEvent('voxalpha:speak-mock', {
+		detail: { text: 'Berlin' }
+	}));
+`
+
+// captureFrames grabs frames at fps until the #transcript element actually
+// gets non-empty text, then keeps recording for tail before stopping. It
+// fails if the transcript never appears within maxWait, rather than
+// silently returning whatever frames happened to be captured.
+func captureFrames(ctx context.Context, fps int, maxWait, tail time.Duration) ([]image.Image, error) {
+	interval := time.Second / time.Duration(fps)
+	deadline := time.Now().Add(maxWait)
+
+	var frames []image.Image
+	var transcriptAt time.Time
+	for {
+		var buf []byte
+		if err := chromedp.Run(ctx, chromedp.CaptureScreenshot(&buf)); err != nil {
+			return nil, err
+		}
+		img, _, err := image.Decode(bytes.NewReader(buf))
+		if err != nil {
+			return nil, fmt.Errorf("decode frame: %w", err)
+		}
+		frames = append(frames, img)
+
+		if transcriptAt.IsZero() {
+			var text string
+			if err := chromedp.Run(ctx, chromedp.Text("#transcript", &text, chromedp.NodeVisible)); err == nil && strings.TrimSpace(text) != "" {
+				transcriptAt = time.Now()
+			} else if time.Now().After(deadline) {
+				return nil, fmt.Errorf("transcript never appeared within %s", maxWait)
+			}
+		} else if time.Now().After(transcriptAt.Add(tail)) {
+			break
+		}
+
+		time.Sleep(interval)
+	}
+	return frames, nil
+}
+
+func scaleFrames(frames []image.Image, scale float64) []image.Image {
+	scaled := make([]image.Image, len(frames))
+	for i, f := range frames {
+		b := f.Bounds()
+		w := int(float64(b.Dx()) * scale)
+		h := int(float64(b.Dy()) * scale)
+		dst := image.NewRGBA(image.Rect(0, 0, w, h))
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				srcX := b.Min.X + int(float64(x)/scale)
+				srcY := b.Min.Y + int(float64(y)/scale)
+				dst.Set(x, y, f.At(srcX, srcY))
+			}
+		}
+		scaled[i] = dst
+	}
+	return scaled
+}
+
+func encodeGIF(f *os.File, frames []image.Image, colors int, fps int) error {
+	q := quantize.MedianCutQuantizer{}
+	delay := 100 / fps // gif.GIF.Delay is in 1/100ths of a second
+
+	anim := &gif.GIF{}
+	for _, frame := range frames {
+		palette := q.Quantize(make(color.Palette, 0, colors), frame)
+		paletted := image.NewPaletted(frame.Bounds(), palette)
+		draw.Draw(paletted, frame.Bounds(), frame, frame.Bounds().Min, draw.Src)
+
+		anim.Image = append(anim.Image, paletted)
+		anim.Delay = append(anim.Delay, delay)
+		anim.Disposal = append(anim.Disposal, gif.DisposalNone)
+	}
+	return gif.EncodeAll(f, anim)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -13,6 +14,13 @@ import (
 )
 
 func main() {
+	format := flag.String("format", "", "record an animated demo instead of the two static screenshots (gif, png, or apng)")
+	colors := flag.Int("colors", 256, "palette size for animated output, 2-256")
+	scale := flag.Float64("scale", 1.0, "scale factor applied to each frame before encoding")
+	fps := flag.Int("fps", 10, "frames per second to capture while recording")
+	script := flag.String("script", "", "run a scripted interaction file instead of the two static screenshots")
+	flag.Parse()
+
 	// Get current working directory and find project root
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -40,6 +48,20 @@ func main() {
 		url = "http://localhost:8080"
 	}
 
+	if *format != "" {
+		if err := recordDemo(url, screenshotsDir, *format, *colors, *scale, *fps); err != nil {
+			log.Fatalf("Failed to record demo: %v", err)
+		}
+		return
+	}
+
+	if *script != "" {
+		if err := runScriptFile(*script, screenshotsDir); err != nil {
+			log.Fatalf("Failed to run script: %v", err)
+		}
+		return
+	}
+
 	fmt.Printf("Taking screenshots of %s\n", url)
 
 	// Create Chrome context with logging disabled to suppress IPAddressSpace warnings
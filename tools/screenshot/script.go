@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	"github.com/jhinrichsen/VoxAlpha/tools/internal/size"
+)
+
+// runScriptFile parses the interaction script at path and runs it against a
+// fresh headless Chrome instance, writing captures into screenshotsDir.
+func runScriptFile(path, screenshotsDir string) error {
+	actions, err := parseActions(path)
+	if err != nil {
+		return err
+	}
+
+	opts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("headless", true),
+		chromedp.Flag("disable-gpu", true),
+		chromedp.Flag("no-sandbox", true),
+	)
+	allocCtx, cancel := chromedp.NewExecAllocator(context.Background(), opts...)
+	defer cancel()
+
+	ctx, cancel := chromedp.NewContext(allocCtx)
+	defer cancel()
+
+	ctx, cancel = context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	return runScript(ctx, screenshotsDir, actions)
+}
+
+// action is one step of a scripted interaction file.
+type action struct {
+	kind string
+	args []string
+}
+
+// parseActions reads a scripted interaction file (one action per line) into
+// a sequence of steps.
+//
+// Supported actions:
+//
+//	navigate URL
+//	viewport WxH
+//	click SELECTOR
+//	type SELECTOR TEXT
+//	waitVisible SELECTOR
+//	waitText SELECTOR SUBSTR
+//	evalFile path.js
+//	sleep DURATION
+//	capture NAME
+func parseActions(path string) ([]action, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open script: %w", err)
+	}
+	defer f.Close()
+
+	var actions []action
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, " ", 2)
+		kind := fields[0]
+		rest := ""
+		if len(fields) == 2 {
+			rest = strings.TrimSpace(fields[1])
+		}
+
+		switch kind {
+		case "navigate", "viewport", "click", "waitVisible", "evalFile", "sleep", "capture":
+			if rest == "" {
+				return nil, fmt.Errorf("line %d: %s requires an argument", lineNo, kind)
+			}
+			actions = append(actions, action{kind: kind, args: []string{rest}})
+		case "type", "waitText":
+			parts := strings.SplitN(rest, " ", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("line %d: %s requires two arguments", lineNo, kind)
+			}
+			actions = append(actions, action{kind: kind, args: parts})
+		default:
+			return nil, fmt.Errorf("line %d: unknown action %q", lineNo, kind)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan script: %w", err)
+	}
+	return actions, nil
+}
+
+// runScript executes a parsed interaction script in order, writing each
+// capture to screenshotsDir/NAME.png.
+func runScript(ctx context.Context, screenshotsDir string, actions []action) error {
+	for _, a := range actions {
+		if err := runAction(ctx, screenshotsDir, a); err != nil {
+			return fmt.Errorf("%s %v: %w", a.kind, a.args, err)
+		}
+	}
+	return nil
+}
+
+func runAction(ctx context.Context, screenshotsDir string, a action) error {
+	switch a.kind {
+	case "navigate":
+		return chromedp.Run(ctx, chromedp.Navigate(a.args[0]))
+	case "viewport":
+		w, h, err := size.Parse(a.args[0])
+		if err != nil {
+			return err
+		}
+		return chromedp.Run(ctx, chromedp.EmulateViewport(int64(w), int64(h)))
+	case "click":
+		return chromedp.Run(ctx, chromedp.Click(a.args[0], chromedp.NodeVisible))
+	case "type":
+		return chromedp.Run(ctx, chromedp.SendKeys(a.args[0], a.args[1]))
+	case "waitVisible":
+		return chromedp.Run(ctx, chromedp.WaitVisible(a.args[0]))
+	case "waitText":
+		selector, substr := a.args[0], a.args[1]
+		return chromedp.Run(ctx,
+			chromedp.WaitVisible(selector),
+			chromedp.Poll(fmt.Sprintf(
+				"document.querySelector(%q).textContent.includes(%q)", selector, substr,
+			), nil),
+		)
+	case "evalFile":
+		src, err := os.ReadFile(a.args[0])
+		if err != nil {
+			return fmt.Errorf("read %s: %w", a.args[0], err)
+		}
+		return chromedp.Run(ctx, chromedp.Evaluate(string(src), nil))
+	case "sleep":
+		d, err := time.ParseDuration(a.args[0])
+		if err != nil {
+			return fmt.Errorf("invalid duration %q: %w", a.args[0], err)
+		}
+		return chromedp.Run(ctx, chromedp.Sleep(d))
+	case "capture":
+		var buf []byte
+		if err := chromedp.Run(ctx, chromedp.CaptureScreenshot(&buf)); err != nil {
+			return err
+		}
+		path := filepath.Join(screenshotsDir, a.args[0]+".png")
+		if err := os.WriteFile(path, buf, 0644); err != nil {
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+		fmt.Printf("✓ Saved %s\n", path)
+		return nil
+	default:
+		return fmt.Errorf("unknown action %q", a.kind)
+	}
+}
+
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"context"
+
+	"github.com/chromedp/chromedp"
+)
+
+// newBrowserContext starts a headless Chrome instance shared across all
+// testcases; captureTestcase opens a fresh tab per testcase from it.
+func newBrowserContext() (context.Context, context.CancelFunc) {
+	opts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("headless", true),
+		chromedp.Flag("disable-gpu", true),
+		chromedp.Flag("no-sandbox", true),
+	)
+	allocCtx, cancelAlloc := chromedp.NewExecAllocator(context.Background(), opts...)
+	ctx, cancelCtx := chromedp.NewContext(allocCtx)
+
+	return ctx, func() {
+		cancelCtx()
+		cancelAlloc()
+	}
+}
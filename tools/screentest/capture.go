@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// captureTestcase navigates to origin+tc.Pathname with the testcase's window
+// size and headers, drives tc.Actions to get the page into the scripted
+// state, then captures a screenshot per tc.Capture.
+func captureTestcase(ctx context.Context, origin string, tc Testcase) ([]byte, error) {
+	tabCtx, cancel := chromedp.NewContext(ctx)
+	defer cancel()
+
+	tabCtx, cancelTimeout := context.WithTimeout(tabCtx, 30*time.Second)
+	defer cancelTimeout()
+
+	url := origin + tc.Pathname
+
+	actions := []chromedp.Action{
+		chromedp.EmulateViewport(int64(tc.Width), int64(tc.Height)),
+	}
+	if len(tc.Headers) > 0 {
+		headers := make(network.Headers, len(tc.Headers))
+		for k, v := range tc.Headers {
+			headers[k] = v
+		}
+		actions = append(actions, network.SetExtraHTTPHeaders(headers))
+	}
+	actions = append(actions,
+		chromedp.Navigate(url),
+		chromedp.Sleep(1*time.Second),
+	)
+
+	for _, a := range tc.Actions {
+		action, err := toChromedpAction(a)
+		if err != nil {
+			return nil, fmt.Errorf("testcase %s: %w", tc.Name, err)
+		}
+		actions = append(actions, action)
+	}
+
+	var buf []byte
+	switch tc.Capture {
+	case CaptureFullscreen:
+		actions = append(actions, chromedp.FullScreenshot(&buf, 100))
+	default:
+		actions = append(actions, chromedp.CaptureScreenshot(&buf))
+	}
+
+	if err := chromedp.Run(tabCtx, actions...); err != nil {
+		return nil, fmt.Errorf("capture %s: %w", tc.Name, err)
+	}
+	return buf, nil
+}
+
+// toChromedpAction translates a parsed script Action into the chromedp
+// action that drives it.
+func toChromedpAction(a Action) (chromedp.Action, error) {
+	switch a.Kind {
+	case "click":
+		return chromedp.Click(a.Args[0], chromedp.NodeVisible), nil
+	case "type":
+		return chromedp.SendKeys(a.Args[0], a.Args[1]), nil
+	case "scroll":
+		// "scroll DX DY" scrolls the window by pixel offsets; anything else,
+		// including selectors with descendant combinators, is a single
+		// selector to scroll into view.
+		if fields := strings.Fields(a.Args[0]); len(fields) == 2 {
+			dx, errX := strconv.Atoi(fields[0])
+			dy, errY := strconv.Atoi(fields[1])
+			if errX == nil && errY == nil {
+				return chromedp.Evaluate(fmt.Sprintf("window.scrollBy(%d, %d)", dx, dy), nil), nil
+			}
+		}
+		return chromedp.ScrollIntoView(a.Args[0]), nil
+	default:
+		return nil, fmt.Errorf("unknown action %q", a.Kind)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// diffResult is the outcome of comparing two screenshots.
+type diffResult struct {
+	Ratio float64 // fraction of pixels that differ, 0..1
+	Image *image.RGBA
+}
+
+// diffImages compares two PNG-encoded screenshots pixel by pixel and produces
+// a diff image highlighting mismatches in red. Differing dimensions count as
+// a full mismatch over the larger bounding box.
+func diffImages(a, b []byte) (*diffResult, error) {
+	imgA, err := png.Decode(bytes.NewReader(a))
+	if err != nil {
+		return nil, fmt.Errorf("decode first image: %w", err)
+	}
+	imgB, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("decode second image: %w", err)
+	}
+
+	boundsA := imgA.Bounds()
+	boundsB := imgB.Bounds()
+	w := boundsA.Dx()
+	if boundsB.Dx() > w {
+		w = boundsB.Dx()
+	}
+	h := boundsA.Dy()
+	if boundsB.Dy() > h {
+		h = boundsB.Dy()
+	}
+
+	out := image.NewRGBA(image.Rect(0, 0, w, h))
+	var mismatches int
+	total := w * h
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			inA := image.Pt(x, y).In(boundsA)
+			inB := image.Pt(x, y).In(boundsB)
+
+			if !inA || !inB {
+				mismatches++
+				out.Set(x, y, color.RGBA{R: 255, A: 255})
+				continue
+			}
+
+			ra, ga, ba, aa := imgA.At(boundsA.Min.X+x, boundsA.Min.Y+y).RGBA()
+			rb, gb, bb, ab := imgB.At(boundsB.Min.X+x, boundsB.Min.Y+y).RGBA()
+
+			if ra != rb || ga != gb || ba != bb || aa != ab {
+				mismatches++
+				out.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				out.Set(x, y, color.RGBA{R: uint8(ra >> 8), G: uint8(ga >> 8), B: uint8(ba >> 8), A: 255})
+			}
+		}
+	}
+
+	ratio := 0.0
+	if total > 0 {
+		ratio = float64(mismatches) / float64(total)
+	}
+	return &diffResult{Ratio: ratio, Image: out}, nil
+}
+
+func encodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,118 @@
+// Command screentest runs script-driven visual regression tests against one
+// or two origins serving the same PWA (e.g. two ports running different
+// branches). With two origins it diffs matching screenshots directly; with
+// one origin it compares against a cached golden image, refreshed via
+// -update.
+//
+// Usage:
+//
+//	screentest [flags] <script.txt> <origin1> [origin2]
+//
+// See script.go for the DSL format.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: screentest [flags] <script.txt> <origin1> [origin2]\n\n")
+		flag.PrintDefaults()
+	}
+	threshold := flag.Float64("threshold", 0.01, "maximum acceptable fraction of differing pixels (0..1)")
+	update := flag.Bool("update", false, "refresh golden images instead of comparing against them")
+	goldenDir := flag.String("golden-dir", "testdata/screentest", "directory holding golden images (single-origin mode)")
+	outDir := flag.String("out", "screentest-out", "directory to write diff images into on failure")
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 2 || len(args) > 3 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	scriptPath := args[0]
+	origins := args[1:]
+
+	testcases, err := ParseScript(scriptPath)
+	if err != nil {
+		log.Fatalf("parse script: %v", err)
+	}
+
+	ctx, cancel := newBrowserContext()
+	defer cancel()
+
+	if err := os.MkdirAll(*outDir, 0755); err != nil {
+		log.Fatalf("create out dir: %v", err)
+	}
+
+	failures := 0
+	for _, tc := range testcases {
+		var got, want []byte
+		var err error
+
+		got, err = captureTestcase(ctx, origins[0], tc)
+		if err != nil {
+			log.Printf("FAIL %s: %v", tc.Name, err)
+			failures++
+			continue
+		}
+
+		if len(origins) == 2 {
+			want, err = captureTestcase(ctx, origins[1], tc)
+			if err != nil {
+				log.Printf("FAIL %s: %v", tc.Name, err)
+				failures++
+				continue
+			}
+		} else {
+			goldenPath := filepath.Join(*goldenDir, tc.Name+".png")
+			if *update {
+				if err := os.MkdirAll(*goldenDir, 0755); err != nil {
+					log.Fatalf("create golden dir: %v", err)
+				}
+				if err := os.WriteFile(goldenPath, got, 0644); err != nil {
+					log.Fatalf("write golden %s: %v", goldenPath, err)
+				}
+				log.Printf("UPDATED %s", tc.Name)
+				continue
+			}
+			want, err = os.ReadFile(goldenPath)
+			if err != nil {
+				log.Printf("FAIL %s: missing golden %s (run with -update to create it)", tc.Name, goldenPath)
+				failures++
+				continue
+			}
+		}
+
+		result, err := diffImages(want, got)
+		if err != nil {
+			log.Printf("FAIL %s: %v", tc.Name, err)
+			failures++
+			continue
+		}
+
+		if result.Ratio > *threshold {
+			diffPath := filepath.Join(*outDir, tc.Name+".diff.png")
+			encoded, encErr := encodePNG(result.Image)
+			if encErr == nil {
+				os.WriteFile(diffPath, encoded, 0644)
+			}
+			log.Printf("FAIL %s: %.4f%% pixels differ (threshold %.4f%%), diff written to %s",
+				tc.Name, result.Ratio*100, *threshold*100, diffPath)
+			failures++
+			continue
+		}
+
+		log.Printf("PASS %s (%.4f%% pixels differ)", tc.Name, result.Ratio*100)
+	}
+
+	if failures > 0 {
+		log.Fatalf("%d/%d testcases failed", failures, len(testcases))
+	}
+	log.Printf("%d testcases passed", len(testcases))
+}
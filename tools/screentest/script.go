@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jhinrichsen/VoxAlpha/tools/internal/size"
+)
+
+// CaptureMode selects how much of the page a testcase screenshot covers.
+type CaptureMode string
+
+const (
+	CaptureViewport   CaptureMode = "viewport"
+	CaptureFullscreen CaptureMode = "fullscreen"
+)
+
+// Testcase is a single scripted scenario to capture and compare.
+type Testcase struct {
+	Name     string
+	Pathname string
+	Width    int
+	Height   int
+	Headers  map[string]string
+	Capture  CaptureMode
+	Actions  []Action
+}
+
+// Action is a pre-capture interaction applied, in order, after navigation
+// and before the screenshot is taken.
+type Action struct {
+	Kind string // "click", "type", or "scroll"
+	Args []string
+}
+
+// ParseScript reads a blank-line-separated DSL file into a list of testcases.
+//
+// Supported directives per testcase:
+//
+//	windowsize WxH
+//	header Key: Value
+//	pathname /path
+//	click SELECTOR
+//	type SELECTOR TEXT
+//	scroll SELECTOR
+//	scroll DX DY
+//	capture fullscreen|viewport
+func ParseScript(path string) ([]Testcase, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open script: %w", err)
+	}
+	defer f.Close()
+
+	var testcases []Testcase
+	cur := newTestcase()
+	hasContent := false
+
+	flush := func() error {
+		if !hasContent {
+			return nil
+		}
+		if cur.Name == "" {
+			cur.Name = deriveName(cur.Pathname, len(testcases))
+		}
+		testcases = append(testcases, cur)
+		cur = newTestcase()
+		hasContent = false
+		return nil
+	}
+
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, " ", 2)
+		directive := fields[0]
+		rest := ""
+		if len(fields) == 2 {
+			rest = strings.TrimSpace(fields[1])
+		}
+
+		switch directive {
+		case "windowsize":
+			w, h, err := size.Parse(rest)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			cur.Width, cur.Height = w, h
+		case "header":
+			k, v, err := parseHeader(rest)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			cur.Headers[k] = v
+		case "pathname":
+			cur.Pathname = rest
+		case "capture":
+			switch CaptureMode(rest) {
+			case CaptureViewport, CaptureFullscreen:
+				cur.Capture = CaptureMode(rest)
+			default:
+				return nil, fmt.Errorf("line %d: unknown capture mode %q", lineNo, rest)
+			}
+		case "name":
+			cur.Name = rest
+		case "click":
+			cur.Actions = append(cur.Actions, Action{Kind: "click", Args: []string{rest}})
+		case "type":
+			parts := strings.SplitN(rest, " ", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("line %d: type requires SELECTOR TEXT", lineNo)
+			}
+			cur.Actions = append(cur.Actions, Action{Kind: "type", Args: parts})
+		case "scroll":
+			cur.Actions = append(cur.Actions, Action{Kind: "scroll", Args: []string{rest}})
+		default:
+			return nil, fmt.Errorf("line %d: unknown directive %q", lineNo, directive)
+		}
+		hasContent = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan script: %w", err)
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	if len(testcases) == 0 {
+		return nil, fmt.Errorf("%s: no testcases found", path)
+	}
+	return testcases, nil
+}
+
+func newTestcase() Testcase {
+	return Testcase{
+		Pathname: "/",
+		Width:    1280,
+		Height:   720,
+		Headers:  map[string]string{},
+		Capture:  CaptureViewport,
+	}
+}
+
+func parseHeader(s string) (string, string, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("header must be Key: Value, got %q", s)
+	}
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), nil
+}
+
+func deriveName(pathname string, index int) string {
+	name := strings.Trim(pathname, "/")
+	name = strings.ReplaceAll(name, "/", "-")
+	if name == "" {
+		name = fmt.Sprintf("testcase-%d", index+1)
+	}
+	return name
+}